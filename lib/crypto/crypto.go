@@ -60,6 +60,12 @@ func CreateAddress(b chain_common.Address, nonce uint64) chain_common.Address {
 	return chain_common.BytesToAddress(Keccak256(data)[12:])
 }
 
+// CreateAddress2 按照CREATE2规则计算合约部署地址：Keccak256(0xff || sender || salt || keccak256(initCode))[12:]。
+// 与基于nonce的CreateAddress不同，该地址在交易发送前即可确定，可用于反事实钱包、状态通道以及元交易中继等场景。
+func CreateAddress2(b chain_common.Address, salt [32]byte, codeHash []byte) chain_common.Address {
+	return chain_common.BytesToAddress(Keccak256([]byte{0xff}, b.Bytes(), salt[:], codeHash)[12:])
+}
+
 // ToECDSA 使用给定的D值创建私钥。
 func ToECDSA(d []byte) (*ecdsa.PrivateKey, error) {
 	return toECDSA(d, true)
@@ -0,0 +1,32 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/aidoc/go-aidoc/lib/chain_common"
+)
+
+func TestCreateAddress2(t *testing.T) {
+	// Known vector from EIP-1014 (CREATE2): sender and salt are all-zero,
+	// init code is the single byte 0x00.
+	sender := chain_common.BytesToAddress(mustDecodeHex(t, "0000000000000000000000000000000000000000"))
+	var salt [32]byte
+	codeHash := Keccak256(mustDecodeHex(t, "00"))
+
+	want := chain_common.BytesToAddress(mustDecodeHex(t, "4d1a2e2bb4f88f0250f26ffff098b0b30b26bf38"))
+
+	if got := CreateAddress2(sender, salt, codeHash); got != want {
+		t.Fatalf("CreateAddress2 = %x, want %x", got, want)
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
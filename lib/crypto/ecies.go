@@ -0,0 +1,148 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/aidoc/go-aidoc/lib/math"
+)
+
+const ecies_ivLength = aes.BlockSize
+
+var (
+	errSharedKeyTooBig = errors.New("共享密钥参数过长，超出曲线阶数限制")
+	errInvalidMessage  = errors.New("无效的密文：长度不足或格式错误")
+	errInvalidMAC      = errors.New("无效的消息认证码")
+)
+
+// GenerateShared 基于ECDH计算priv与pub之间的共享密钥，并校验其长度满足skLen+macLen字节的需求。
+// 返回值是共享点S=priv·pub的横坐标Sx按曲线字段宽度大端填充后的末尾skLen+macLen字节，
+// 供Encrypt/Decrypt派生对称密钥与MAC密钥使用。
+func GenerateShared(priv *ecdsa.PrivateKey, pub *ecdsa.PublicKey, skLen, macLen int) ([]byte, error) {
+	if priv.Curve != pub.Curve {
+		return nil, errInvalidPubkey
+	}
+	if skLen+macLen > (priv.Curve.Params().BitSize+7)/8 {
+		return nil, errSharedKeyTooBig
+	}
+	x, y := priv.Curve.ScalarMult(pub.X, pub.Y, priv.D.Bytes())
+	if x == nil || y == nil {
+		return nil, errInvalidPubkey
+	}
+	fieldWidth := (priv.Curve.Params().BitSize + 7) / 8
+	xFull := math.PaddedBigBytes(x, fieldWidth)
+
+	sk := make([]byte, skLen+macLen)
+	copy(sk, xFull[fieldWidth-len(sk):])
+	return sk, nil
+}
+
+// concatKDF实现NIST SP 800-56中基于SHA-256的Concatenation KDF，
+// 从共享密钥z与附加信息s1派生出长度为kdLen的密钥材料。
+func concatKDF(z, s1 []byte, kdLen int) []byte {
+	counterBytes := make([]byte, 4)
+	k := make([]byte, 0, kdLen+sha256.Size)
+	for counter := uint32(1); len(k) < kdLen; counter++ {
+		binary.BigEndian.PutUint32(counterBytes, counter)
+		h := sha256.New()
+		h.Write(counterBytes)
+		h.Write(z)
+		h.Write(s1)
+		k = h.Sum(k)
+	}
+	return k[:kdLen]
+}
+
+// messageTag对IV||ct||s2计算HMAC-SHA256，作为密文的认证标签。
+func messageTag(km, iv, ct, s2 []byte) []byte {
+	mac := hmac.New(sha256.New, km)
+	mac.Write(iv)
+	mac.Write(ct)
+	mac.Write(s2)
+	return mac.Sum(nil)
+}
+
+// Encrypt使用ECIES将plaintext加密给pub对应私钥的持有者。
+// 流程为：生成临时密钥对(r, R)，计算共享点S=r·pub，并通过concat-KDF从Sx派生出
+// AES-128-CTR加密密钥与HMAC-SHA256认证密钥；密文格式为 0x04 || Rx || Ry || IV || ct || tag。
+func Encrypt(pub *ecdsa.PublicKey, plaintext, s1, s2 []byte) ([]byte, error) {
+	r, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	z, err := GenerateShared(r, pub, 16, 16)
+	if err != nil {
+		return nil, err
+	}
+	K := concatKDF(z, s1, 32)
+	Ke, Km := K[:16], K[16:]
+	kmHash := sha256.Sum256(Km)
+	Km = kmHash[:]
+
+	iv := make([]byte, ecies_ivLength)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(Ke)
+	if err != nil {
+		return nil, err
+	}
+	ct := make([]byte, len(plaintext))
+	cipher.NewCTR(block, iv).XORKeyStream(ct, plaintext)
+
+	tag := messageTag(Km, iv, ct, s2)
+
+	Rb := FromECDSAPub(&r.PublicKey)
+	out := make([]byte, 0, len(Rb)+len(iv)+len(ct)+len(tag))
+	out = append(out, Rb...)
+	out = append(out, iv...)
+	out = append(out, ct...)
+	out = append(out, tag...)
+	return out, nil
+}
+
+// Decrypt对Encrypt产生的密文解密。会先以常数时间校验HMAC标签，
+// 验证通过后才使用AES-128-CTR还原明文。
+func Decrypt(priv *ecdsa.PrivateKey, ciphertext, s1, s2 []byte) ([]byte, error) {
+	const pubLen = 65 // 0x04 || X || Y，secp256k1未压缩公钥长度
+	if len(ciphertext) < pubLen+ecies_ivLength+sha256.Size {
+		return nil, errInvalidMessage
+	}
+	Rb := ciphertext[:pubLen]
+	iv := ciphertext[pubLen : pubLen+ecies_ivLength]
+	ct := ciphertext[pubLen+ecies_ivLength : len(ciphertext)-sha256.Size]
+	tag := ciphertext[len(ciphertext)-sha256.Size:]
+
+	R, err := UnmarshalPubkey(Rb)
+	if err != nil {
+		return nil, err
+	}
+	z, err := GenerateShared(priv, R, 16, 16)
+	if err != nil {
+		return nil, err
+	}
+	K := concatKDF(z, s1, 32)
+	Ke, Km := K[:16], K[16:]
+	kmHash := sha256.Sum256(Km)
+	Km = kmHash[:]
+
+	if subtle.ConstantTimeCompare(messageTag(Km, iv, ct, s2), tag) != 1 {
+		return nil, errInvalidMAC
+	}
+
+	block, err := aes.NewCipher(Ke)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(ct))
+	cipher.NewCTR(block, iv).XORKeyStream(plaintext, ct)
+	return plaintext, nil
+}
@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte("this is a secret aidoc message")
+	s1 := []byte("shared-info-1")
+	s2 := []byte("shared-info-2")
+
+	ct, err := Encrypt(&priv.PublicKey, plaintext, s1, s2)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	pt, err := Decrypt(priv, ct, s1, s2)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !bytes.Equal(pt, plaintext) {
+		t.Fatalf("roundtrip mismatch: got %x, want %x", pt, plaintext)
+	}
+}
+
+func TestDecryptRejectsMismatchedS2(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := Encrypt(&priv.PublicKey, []byte("hello"), nil, []byte("s2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(priv, ct, nil, []byte("wrong-s2")); err == nil {
+		t.Fatal("expected MAC verification failure, got nil error")
+	}
+}
+
+func TestGenerateSharedRespectsRequestedLength(t *testing.T) {
+	priv1, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv2, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, err := GenerateShared(priv1, &priv2.PublicKey, 16, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sk) != 16 {
+		t.Fatalf("expected 16-byte shared secret, got %d bytes", len(sk))
+	}
+
+	sk2, err := GenerateShared(priv1, &priv2.PublicKey, 16, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sk2) != 32 {
+		t.Fatalf("expected 32-byte shared secret, got %d bytes", len(sk2))
+	}
+}
@@ -0,0 +1,182 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+)
+
+// secp256k1OID是SEC 1/RFC5480中为secp256k1曲线分配的对象标识符。
+// Go标准库的crypto/x509并未注册该曲线，因此这里需要手工构造ASN.1结构。
+var secp256k1OID = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+// ecPublicKeyOID是RFC5480中id-ecPublicKey算法标识符。
+var ecPublicKeyOID = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+const (
+	pemTypeECPrivateKey = "EC PRIVATE KEY"
+	pemTypePKCS8Key     = "PRIVATE KEY"
+	pemTypePublicKey    = "PUBLIC KEY"
+)
+
+var errInvalidPEMBlock = errors.New("无效的PEM块：类型或内容不匹配")
+
+// ecPrivateKey对应SEC 1（RFC5915）中定义的ECPrivateKey ASN.1结构。
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8Key对应PKCS#8（RFC5208）中定义的PrivateKeyInfo ASN.1结构。
+type pkcs8Key struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// pkixPublicKey对应RFC5480中定义的SubjectPublicKeyInfo ASN.1结构。
+type pkixPublicKey struct {
+	Algo      pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MarshalECDSAPrivatePEM将secp256k1私钥编码为SEC1格式的"EC PRIVATE KEY"PEM块，
+// 以便与OpenSSL及其他通用Go/OpenSSL工具互通。
+func MarshalECDSAPrivatePEM(priv *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    FromECDSA(priv),
+		NamedCurveOID: secp256k1OID,
+		PublicKey:     asn1.BitString{Bytes: FromECDSAPub(&priv.PublicKey)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeECPrivateKey, Bytes: der}), nil
+}
+
+// UnmarshalECDSAPrivatePEM解析SEC1（"EC PRIVATE KEY"）或PKCS8（"PRIVATE KEY"）格式的PEM块，
+// 还原出secp256k1私钥。
+func UnmarshalECDSAPrivatePEM(pemBytes []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errInvalidPEMBlock
+	}
+
+	switch block.Type {
+	case pemTypeECPrivateKey:
+		var key ecPrivateKey
+		if _, err := asn1.Unmarshal(block.Bytes, &key); err != nil {
+			return nil, err
+		}
+		if len(key.NamedCurveOID) > 0 && !key.NamedCurveOID.Equal(secp256k1OID) {
+			return nil, errInvalidPEMBlock
+		}
+		return ToECDSA(key.PrivateKey)
+	case pemTypePKCS8Key:
+		var wrapper pkcs8Key
+		if _, err := asn1.Unmarshal(block.Bytes, &wrapper); err != nil {
+			return nil, err
+		}
+		if !wrapper.Algo.Algorithm.Equal(ecPublicKeyOID) {
+			return nil, errInvalidPEMBlock
+		}
+		var curveOID asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(wrapper.Algo.Parameters.FullBytes, &curveOID); err != nil || !curveOID.Equal(secp256k1OID) {
+			return nil, errInvalidPEMBlock
+		}
+		var key ecPrivateKey
+		if _, err := asn1.Unmarshal(wrapper.PrivateKey, &key); err != nil {
+			return nil, err
+		}
+		return ToECDSA(key.PrivateKey)
+	default:
+		return nil, errInvalidPEMBlock
+	}
+}
+
+// MarshalECDSAPrivatePKCS8PEM将secp256k1私钥编码为PKCS8格式的"PRIVATE KEY"PEM块。
+func MarshalECDSAPrivatePKCS8PEM(priv *ecdsa.PrivateKey) ([]byte, error) {
+	inner, err := asn1.Marshal(ecPrivateKey{
+		Version:    1,
+		PrivateKey: FromECDSA(priv),
+		PublicKey:  asn1.BitString{Bytes: FromECDSAPub(&priv.PublicKey)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	oidBytes, err := asn1.Marshal(secp256k1OID)
+	if err != nil {
+		return nil, err
+	}
+	der, err := asn1.Marshal(pkcs8Key{
+		Version: 0,
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  ecPublicKeyOID,
+			Parameters: asn1.RawValue{FullBytes: oidBytes},
+		},
+		PrivateKey: inner,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8Key, Bytes: der}), nil
+}
+
+// MarshalECDSAPubPEM将secp256k1公钥编码为SubjectPublicKeyInfo格式的"PUBLIC KEY"PEM块。
+func MarshalECDSAPubPEM(pub *ecdsa.PublicKey) ([]byte, error) {
+	oidBytes, err := asn1.Marshal(secp256k1OID)
+	if err != nil {
+		return nil, err
+	}
+	der, err := asn1.Marshal(pkixPublicKey{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  ecPublicKeyOID,
+			Parameters: asn1.RawValue{FullBytes: oidBytes},
+		},
+		PublicKey: asn1.BitString{Bytes: FromECDSAPub(pub)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// UnmarshalECDSAPubPEM解析"PUBLIC KEY"格式的PEM块，还原出secp256k1公钥。
+func UnmarshalECDSAPubPEM(pemBytes []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != pemTypePublicKey {
+		return nil, errInvalidPEMBlock
+	}
+	var wrapper pkixPublicKey
+	if _, err := asn1.Unmarshal(block.Bytes, &wrapper); err != nil {
+		return nil, err
+	}
+	if !wrapper.Algo.Algorithm.Equal(ecPublicKeyOID) {
+		return nil, errInvalidPEMBlock
+	}
+	return UnmarshalPubkey(wrapper.PublicKey.Bytes)
+}
+
+// LoadECDSAPEM从给定文件加载PEM编码的secp256k1私钥（支持SEC1与PKCS8两种格式）。
+func LoadECDSAPEM(file string) (*ecdsa.PrivateKey, error) {
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalECDSAPrivatePEM(buf)
+}
+
+// SaveECDSAPEM使用限制权限（0600）将secp256k1私钥以SEC1 PEM格式保存到给定文件。
+func SaveECDSAPEM(file string, priv *ecdsa.PrivateKey) error {
+	pemBytes, err := MarshalECDSAPrivatePEM(priv)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, pemBytes, 0600)
+}
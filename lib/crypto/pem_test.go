@@ -0,0 +1,143 @@
+package crypto
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"testing"
+)
+
+func TestMarshalUnmarshalECDSAPrivatePEM(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemBytes, err := MarshalECDSAPrivatePEM(priv)
+	if err != nil {
+		t.Fatalf("MarshalECDSAPrivatePEM failed: %v", err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != pemTypeECPrivateKey {
+		t.Fatalf("expected a %q PEM block, got %+v", pemTypeECPrivateKey, block)
+	}
+
+	got, err := UnmarshalECDSAPrivatePEM(pemBytes)
+	if err != nil {
+		t.Fatalf("UnmarshalECDSAPrivatePEM failed: %v", err)
+	}
+	if got.D.Cmp(priv.D) != 0 {
+		t.Fatalf("roundtrip mismatch: got D=%x, want D=%x", got.D, priv.D)
+	}
+}
+
+func TestMarshalUnmarshalECDSAPrivatePKCS8PEM(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemBytes, err := MarshalECDSAPrivatePKCS8PEM(priv)
+	if err != nil {
+		t.Fatalf("MarshalECDSAPrivatePKCS8PEM failed: %v", err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != pemTypePKCS8Key {
+		t.Fatalf("expected a %q PEM block, got %+v", pemTypePKCS8Key, block)
+	}
+
+	got, err := UnmarshalECDSAPrivatePEM(pemBytes)
+	if err != nil {
+		t.Fatalf("UnmarshalECDSAPrivatePEM failed: %v", err)
+	}
+	if got.D.Cmp(priv.D) != 0 {
+		t.Fatalf("roundtrip mismatch: got D=%x, want D=%x", got.D, priv.D)
+	}
+}
+
+func TestMarshalUnmarshalECDSAPubPEM(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemBytes, err := MarshalECDSAPubPEM(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalECDSAPubPEM failed: %v", err)
+	}
+
+	got, err := UnmarshalECDSAPubPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("UnmarshalECDSAPubPEM failed: %v", err)
+	}
+	if got.X.Cmp(priv.PublicKey.X) != 0 || got.Y.Cmp(priv.PublicKey.Y) != 0 {
+		t.Fatalf("roundtrip mismatch: got (%x,%x), want (%x,%x)", got.X, got.Y, priv.PublicKey.X, priv.PublicKey.Y)
+	}
+}
+
+func TestUnmarshalECDSAPrivatePEMRejectsWrongCurveOID(t *testing.T) {
+	// prime256v1 (P-256) OID, used here to stand in for any curve other than secp256k1.
+	wrongCurveOID := asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("SEC1", func(t *testing.T) {
+		der, err := asn1.Marshal(ecPrivateKey{
+			Version:       1,
+			PrivateKey:    FromECDSA(priv),
+			NamedCurveOID: wrongCurveOID,
+			PublicKey:     asn1.BitString{Bytes: FromECDSAPub(&priv.PublicKey)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pemBytes := pem.EncodeToMemory(&pem.Block{Type: pemTypeECPrivateKey, Bytes: der})
+		if _, err := UnmarshalECDSAPrivatePEM(pemBytes); err != errInvalidPEMBlock {
+			t.Fatalf("expected errInvalidPEMBlock, got %v", err)
+		}
+	})
+
+	t.Run("PKCS8", func(t *testing.T) {
+		inner, err := asn1.Marshal(ecPrivateKey{
+			Version:    1,
+			PrivateKey: FromECDSA(priv),
+			PublicKey:  asn1.BitString{Bytes: FromECDSAPub(&priv.PublicKey)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		oidBytes, err := asn1.Marshal(wrongCurveOID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := asn1.Marshal(pkcs8Key{
+			Version: 0,
+			Algo: pkix.AlgorithmIdentifier{
+				Algorithm:  ecPublicKeyOID,
+				Parameters: asn1.RawValue{FullBytes: oidBytes},
+			},
+			PrivateKey: inner,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pemBytes := pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8Key, Bytes: der})
+		if _, err := UnmarshalECDSAPrivatePEM(pemBytes); err != errInvalidPEMBlock {
+			t.Fatalf("expected errInvalidPEMBlock, got %v", err)
+		}
+	})
+}
+
+func TestUnmarshalECDSAPrivatePEMRejectsGarbage(t *testing.T) {
+	if _, err := UnmarshalECDSAPrivatePEM([]byte("not a pem block at all")); err != errInvalidPEMBlock {
+		t.Fatalf("expected errInvalidPEMBlock for non-PEM input, got %v", err)
+	}
+
+	truncated := pem.EncodeToMemory(&pem.Block{Type: pemTypeECPrivateKey, Bytes: []byte{0x01, 0x02}})
+	if _, err := UnmarshalECDSAPrivatePEM(truncated); err == nil {
+		t.Fatal("expected an error for truncated ASN.1 content, got nil")
+	}
+}
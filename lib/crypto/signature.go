@@ -0,0 +1,117 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/aidoc/go-aidoc/lib/crypto/secp256k1"
+	"github.com/aidoc/go-aidoc/lib/i18"
+	"github.com/aidoc/go-aidoc/lib/math"
+)
+
+const (
+	// DigestLength是待签名摘要的字节长度（如Keccak256的输出长度）。
+	DigestLength = 32
+	// SignatureLength是签名的字节长度：64字节的[R || S]加上1字节的恢复ID。
+	SignatureLength = 64 + 1
+	// RecoveryIDOffset是签名中恢复ID（V）所在的字节偏移。
+	RecoveryIDOffset = 64
+)
+
+// Sign使用私钥priv对32字节摘要digestHash计算ECDSA签名，返回紧凑格式[R || S || V]，
+// 其中V的取值为0或1。
+//
+// 该函数容易受到选择明文攻击影响，可能泄露签名私钥的相关信息：调用方必须保证digestHash
+// 不能由攻击者任意选取，常见的做法是在签名前先对原始输入做一次哈希。
+func Sign(digestHash []byte, priv *ecdsa.PrivateKey) (sig []byte, err error) {
+	if len(digestHash) != DigestLength {
+		return nil, fmt.Errorf(i18.I18_print.Sprintf("摘要长度错误，需要 %d 字节（实际 %d 字节）", DigestLength, len(digestHash)))
+	}
+	seckey := math.PaddedBigBytes(priv.D, priv.Params().BitSize/8)
+	defer zeroBytes(seckey)
+	return secp256k1.Sign(digestHash, seckey)
+}
+
+// Ecrecover通过摘要与签名恢复出未压缩格式的公钥字节。
+func Ecrecover(hash, sig []byte) ([]byte, error) {
+	return secp256k1.RecoverPubkey(hash, sig)
+}
+
+// SigToPub通过摘要与签名恢复出对应的公钥。
+func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	s, err := Ecrecover(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	x, y := elliptic.Unmarshal(S256(), s)
+	return &ecdsa.PublicKey{Curve: S256(), X: x, Y: y}, nil
+}
+
+// VerifySignature校验pubkey是否对digestHash产生了signature。
+// pubkey可以是压缩（33字节）或未压缩（65字节）格式，signature必须是不带恢复ID的64字节[R || S]格式，
+// 并按照ValidateSignatureValues中已有的secp256k1约定拒绝可延展的高S值签名。
+func VerifySignature(pubkey, digestHash, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	var r, s big.Int
+	r.SetBytes(signature[:32])
+	s.SetBytes(signature[32:])
+	if !ValidateSignatureValues(0, &r, &s, true) {
+		return false
+	}
+	return secp256k1.VerifySignature(pubkey, digestHash, signature)
+}
+
+// ChainRules描述在给定链/分叉下生效的一组签名校验规则，供ValidateSignatureValuesEIP155使用。
+type ChainRules struct {
+	Homestead bool // 是否启用Homestead分叉规则
+	EIP155    bool // 是否启用EIP-155链ID重放保护，启用时要求签名携带有效chainID
+	EIP2      bool // 是否强制要求低S值（Homestead之后恒为true）
+}
+
+// NormalizeV将交易空间编码的V值规约为规范的0/1恢复ID。V可以是未做链ID保护的27/28，
+// 也可以是EIP-155保护下的35+2*chainID或36+2*chainID。
+func NormalizeV(v *big.Int, chainID *big.Int) (byte, error) {
+	if v == nil {
+		return 0, errors.New("v不能为空")
+	}
+	if chainID != nil && chainID.Sign() > 0 {
+		offset := new(big.Int).Sub(v, new(big.Int).Mul(chainID, big.NewInt(2)))
+		offset.Sub(offset, big.NewInt(35))
+		if offset.Sign() == 0 || offset.Cmp(big.NewInt(1)) == 0 {
+			return byte(offset.Uint64()), nil
+		}
+	}
+	switch {
+	case v.Cmp(big.NewInt(0)) == 0, v.Cmp(big.NewInt(1)) == 0:
+		return byte(v.Uint64()), nil
+	case v.Cmp(big.NewInt(27)) == 0, v.Cmp(big.NewInt(28)) == 0:
+		return byte(v.Uint64() - 27), nil
+	}
+	return 0, fmt.Errorf(i18.I18_print.Sprintf("无效的签名V值: %s", v.String()))
+}
+
+// EncodeV将规范的0/1恢复ID编码为交易空间的V值：chainID为空或为0时产生未受保护的27/28，
+// 否则按EIP-155规则产生35+2*chainID+recID，与Sign配合即可生成可用于签名交易的V。
+func EncodeV(recID byte, chainID *big.Int) *big.Int {
+	v := big.NewInt(int64(recID))
+	if chainID == nil || chainID.Sign() == 0 {
+		return v.Add(v, big.NewInt(27))
+	}
+	v.Add(v, new(big.Int).Mul(chainID, big.NewInt(2)))
+	return v.Add(v, big.NewInt(35))
+}
+
+// ValidateSignatureValuesEIP155在ValidateSignatureValues的基础上加入链ID重放保护校验：
+// rules.EIP155启用时要求chainID非空且为正；r、s的范围以及低S值要求仍由rules.Homestead/EIP2控制，
+// v须已经是NormalizeV规约后的0/1恢复ID。
+func ValidateSignatureValuesEIP155(v byte, r, s *big.Int, chainID *big.Int, rules ChainRules) bool {
+	if rules.EIP155 && (chainID == nil || chainID.Sign() <= 0) {
+		return false
+	}
+	return ValidateSignatureValues(v, r, s, rules.Homestead || rules.EIP2)
+}
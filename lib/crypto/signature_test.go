@@ -0,0 +1,93 @@
+package crypto
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestSignAndEcrecover(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := Keccak256([]byte("sign me"))
+
+	sig, err := Sign(digest, priv)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len(sig) != SignatureLength {
+		t.Fatalf("expected signature of length %d, got %d", SignatureLength, len(sig))
+	}
+
+	recovered, err := Ecrecover(digest, sig)
+	if err != nil {
+		t.Fatalf("Ecrecover failed: %v", err)
+	}
+	if !bytes.Equal(recovered, FromECDSAPub(&priv.PublicKey)) {
+		t.Fatalf("recovered pubkey mismatch")
+	}
+
+	pub, err := SigToPub(digest, sig)
+	if err != nil {
+		t.Fatalf("SigToPub failed: %v", err)
+	}
+	if pub.X.Cmp(priv.PublicKey.X) != 0 || pub.Y.Cmp(priv.PublicKey.Y) != 0 {
+		t.Fatalf("SigToPub returned a different public key")
+	}
+
+	if !VerifySignature(FromECDSAPub(&priv.PublicKey), digest, sig[:64]) {
+		t.Fatalf("VerifySignature rejected a valid signature")
+	}
+}
+
+func TestSignRejectsWrongDigestLength(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sign([]byte("too short"), priv); err == nil {
+		t.Fatal("expected error for a digest that is not 32 bytes")
+	}
+}
+
+func TestEncodeVNormalizeVRoundtrip(t *testing.T) {
+	chainIDs := []*big.Int{nil, big.NewInt(0), big.NewInt(1), big.NewInt(10)}
+	for _, chainID := range chainIDs {
+		for _, recID := range []byte{0, 1} {
+			v := EncodeV(recID, chainID)
+			got, err := NormalizeV(v, chainID)
+			if err != nil {
+				t.Fatalf("NormalizeV(%s, %v) failed: %v", v, chainID, err)
+			}
+			if got != recID {
+				t.Fatalf("roundtrip mismatch: chainID=%v recID=%d got=%d", chainID, recID, got)
+			}
+		}
+	}
+}
+
+func TestNormalizeVLegacy(t *testing.T) {
+	want := map[int64]byte{27: 0, 28: 1}
+	for v, rec := range want {
+		got, err := NormalizeV(big.NewInt(v), nil)
+		if err != nil {
+			t.Fatalf("NormalizeV(%d, nil) failed: %v", v, err)
+		}
+		if got != rec {
+			t.Fatalf("NormalizeV(%d, nil) = %d, want %d", v, got, rec)
+		}
+	}
+}
+
+func TestValidateSignatureValuesEIP155RequiresChainID(t *testing.T) {
+	r := big.NewInt(1)
+	s := big.NewInt(1)
+	if ValidateSignatureValuesEIP155(0, r, s, nil, ChainRules{EIP155: true}) {
+		t.Fatal("expected EIP155 rules to reject a missing chainID")
+	}
+	if !ValidateSignatureValuesEIP155(0, r, s, big.NewInt(1), ChainRules{EIP155: true, Homestead: true}) {
+		t.Fatal("expected a valid signature with a chainID to pass")
+	}
+}